@@ -0,0 +1,142 @@
+//! # frozen-duckdb CLI
+//!
+//! Thin command-line entry point over the migrations package, so schema
+//! changes can be applied the same way whether they're driven from Go code
+//! (e.g. the smoke test bringing an in-memory DB to a known Flock-enabled
+//! state) or from a shell.
+//!
+//! ## Usage
+//!
+//! ```bash
+//! frozen-duckdb migrate up|down|version [-db path] [-dir migrations]
+//! ```
+
+package main
+
+/*
+#cgo CFLAGS: -I.
+#include "duckdb_ffi.h"
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"unsafe"
+
+	"kcura_go/migrations"
+)
+
+// duckdbExecutor adapts a raw DuckDB connection to migrations.Executor.
+type duckdbExecutor struct {
+	conn C.duckdb_connection
+}
+
+func (e *duckdbExecutor) Exec(ctx context.Context, sql string) error {
+	cSQL := C.CString(sql)
+	defer C.free(unsafe.Pointer(cSQL))
+
+	var result C.duckdb_result
+	defer C.duckdb_destroy_result(&result)
+
+	if state := C.duckdb_query(e.conn, cSQL, &result); state != C.DuckDBSuccess {
+		errorMsg := C.duckdb_result_error(&result)
+		if errorMsg != nil {
+			return fmt.Errorf("%s", C.GoString(errorMsg))
+		}
+		return fmt.Errorf("statement failed: %s", sql)
+	}
+	return nil
+}
+
+func (e *duckdbExecutor) QueryVersion(ctx context.Context) (int64, bool, bool, error) {
+	cSQL := C.CString(fmt.Sprintf(
+		"SELECT version, dirty FROM %s ORDER BY version DESC LIMIT 1", migrations.SchemaMigrationsTable,
+	))
+	defer C.free(unsafe.Pointer(cSQL))
+
+	var result C.duckdb_result
+	defer C.duckdb_destroy_result(&result)
+
+	if state := C.duckdb_query(e.conn, cSQL, &result); state != C.DuckDBSuccess {
+		// Table doesn't exist yet: treat as version 0, not dirty.
+		return 0, false, false, nil
+	}
+	if C.duckdb_row_count(&result) == 0 {
+		return 0, false, false, nil
+	}
+	version := int64(C.duckdb_value_int64(&result, 0, 0))
+	dirty := bool(C.duckdb_value_boolean(&result, 1, 0))
+	return version, dirty, true, nil
+}
+
+func (e *duckdbExecutor) BeginTx(ctx context.Context) error  { return e.Exec(ctx, "BEGIN TRANSACTION") }
+func (e *duckdbExecutor) Commit(ctx context.Context) error   { return e.Exec(ctx, "COMMIT") }
+func (e *duckdbExecutor) Rollback(ctx context.Context) error { return e.Exec(ctx, "ROLLBACK") }
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "migrate" {
+		fmt.Fprintln(os.Stderr, "usage: frozen-duckdb migrate up|down|version [-db path] [-dir migrations]")
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dbPath := fs.String("db", ":memory:", "path to the DuckDB database file")
+	dir := fs.String("dir", "migrations", "directory of NNNN_name.{up,down}.sql files")
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, "usage: frozen-duckdb migrate up|down|version")
+		os.Exit(2)
+	}
+	subcommand := os.Args[2]
+	fs.Parse(os.Args[3:])
+
+	var db C.duckdb_database
+	cPath := C.CString(*dbPath)
+	defer C.free(unsafe.Pointer(cPath))
+	if state := C.duckdb_open(cPath, &db); state != C.DuckDBSuccess {
+		fmt.Fprintf(os.Stderr, "failed to open database %q\n", *dbPath)
+		os.Exit(1)
+	}
+	defer C.duckdb_close(&db)
+
+	var conn C.duckdb_connection
+	if state := C.duckdb_connect(db, &conn); state != C.DuckDBSuccess {
+		fmt.Fprintln(os.Stderr, "failed to connect to database")
+		os.Exit(1)
+	}
+	defer C.duckdb_disconnect(&conn)
+
+	exec := &duckdbExecutor{conn: conn}
+	src := migrations.NewFSSource(os.DirFS(*dir))
+	migrator := migrations.New(exec, src)
+	ctx := context.Background()
+
+	switch subcommand {
+	case "up":
+		if err := migrator.Up(ctx, 0); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	case "down":
+		if err := migrator.Down(ctx, 1); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	case "version":
+		version, dirty, err := migrator.Version(ctx)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		suffix := ""
+		if dirty {
+			suffix = " (dirty)"
+		}
+		fmt.Printf("%d%s\n", version, suffix)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown migrate subcommand %q\n", subcommand)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,279 @@
+// Package migrations implements a golang-migrate-style schema migration
+// runner for frozen-duckdb. It discovers versioned NNNN_name.up.sql /
+// NNNN_name.down.sql pairs from a Source, tracks applied versions in a
+// schema_migrations table, and applies them atomically. Every migration is
+// SQL text; there is no form of migration that runs a Go function.
+package migrations
+
+import (
+	"context"
+	"fmt"
+)
+
+// SchemaMigrationsTable is the name of the bookkeeping table the Migrator
+// creates on first use.
+const SchemaMigrationsTable = "schema_migrations"
+
+// Executor is the minimal surface a DuckDB connection wrapper needs to
+// provide for the Migrator to run migrations against it. It deliberately
+// does not depend on any concrete connection type so the migrations
+// package stays usable from the CLI, the smoke test, or application code.
+type Executor interface {
+	// Exec runs sql with no expectation of returned rows.
+	Exec(ctx context.Context, sql string) error
+	// QueryVersion returns the single (version, dirty) row from
+	// schema_migrations, or ok=false if the table is empty or missing.
+	QueryVersion(ctx context.Context) (version int64, dirty bool, ok bool, err error)
+	// BeginTx/Commit/Rollback bracket a single migration's SQL plus its
+	// schema_migrations bookkeeping update in one DuckDB transaction.
+	BeginTx(ctx context.Context) error
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
+
+// Migration is one discovered schema version, with optional up/down SQL.
+// NoTransaction must be set for statements DuckDB cannot run inside a
+// transaction (e.g. INSTALL/LOAD).
+type Migration struct {
+	Version       int64
+	Name          string
+	Up            string
+	Down          string
+	NoTransaction bool
+}
+
+// Source discovers the full set of migrations available to a Migrator,
+// ordered by version. FSSource (for os.DirFS and embed.FS) and
+// StaticSource are the two implementations frozen-duckdb ships.
+type Source interface {
+	Migrations() ([]Migration, error)
+}
+
+// Migrator applies and reverts Migrations against an Executor, recording
+// progress in schema_migrations so repeated runs are idempotent.
+type Migrator struct {
+	exec Executor
+	src  Source
+}
+
+// New returns a Migrator that will apply migrations from src against exec.
+// It does not touch the database until Up, Down, Goto, Force, or Version
+// is called.
+func New(exec Executor, src Source) *Migrator {
+	return &Migrator{exec: exec, src: src}
+}
+
+func (m *Migrator) ensureSchemaTable(ctx context.Context) error {
+	return m.exec.Exec(ctx, fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (version BIGINT PRIMARY KEY, dirty BOOLEAN, applied_at TIMESTAMP)`,
+		SchemaMigrationsTable,
+	))
+}
+
+// Version returns the currently applied version and whether the last
+// migration left the database in a dirty (partially applied) state.
+func (m *Migrator) Version(ctx context.Context) (version int64, dirty bool, err error) {
+	if err := m.ensureSchemaTable(ctx); err != nil {
+		return 0, false, err
+	}
+	version, dirty, _, err = m.exec.QueryVersion(ctx)
+	return version, dirty, err
+}
+
+// Up applies the next n pending migrations in order. n <= 0 applies all
+// pending migrations.
+func (m *Migrator) Up(ctx context.Context, n int) error {
+	return m.apply(ctx, n, true)
+}
+
+// Down reverts the last n applied migrations in reverse order. n <= 0
+// reverts every applied migration.
+func (m *Migrator) Down(ctx context.Context, n int) error {
+	return m.apply(ctx, n, false)
+}
+
+// Goto migrates forward or backward until the database is at exactly
+// version.
+func (m *Migrator) Goto(ctx context.Context, version uint) error {
+	current, dirty, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migrations: database is dirty at version %d, run Force first", current)
+	}
+
+	target := int64(version)
+	if target == current {
+		return nil
+	}
+	if target > current {
+		return m.applyRange(ctx, current, target, true)
+	}
+	return m.applyRange(ctx, current, target, false)
+}
+
+// Force sets the recorded version without running any migration SQL,
+// clearing the dirty flag. Use this to recover from a migration that
+// failed partway and left the database dirty.
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	if err := m.ensureSchemaTable(ctx); err != nil {
+		return err
+	}
+	if err := m.exec.BeginTx(ctx); err != nil {
+		return err
+	}
+	if err := m.exec.Exec(ctx, fmt.Sprintf("DELETE FROM %s", SchemaMigrationsTable)); err != nil {
+		m.exec.Rollback(ctx)
+		return err
+	}
+	if err := m.exec.Exec(ctx, fmt.Sprintf(
+		"INSERT INTO %s (version, dirty, applied_at) VALUES (%d, false, now())",
+		SchemaMigrationsTable, version,
+	)); err != nil {
+		m.exec.Rollback(ctx)
+		return err
+	}
+	return m.exec.Commit(ctx)
+}
+
+func (m *Migrator) apply(ctx context.Context, n int, up bool) error {
+	current, dirty, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migrations: database is dirty at version %d, run Force first", current)
+	}
+
+	all, err := m.src.Migrations()
+	if err != nil {
+		return err
+	}
+
+	pending := pendingMigrations(all, current, up)
+	if n > 0 && n < len(pending) {
+		pending = pending[:n]
+	}
+
+	for _, mig := range pending {
+		if err := m.applyOne(ctx, mig, up); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) applyRange(ctx context.Context, from int64, to int64, up bool) error {
+	all, err := m.src.Migrations()
+	if err != nil {
+		return err
+	}
+	for _, mig := range pendingMigrations(all, from, up) {
+		if up && mig.Version > to {
+			break
+		}
+		if !up && mig.Version <= to {
+			break
+		}
+		if err := m.applyOne(ctx, mig, up); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyOne runs a single migration's SQL and bookkeeping update atomically
+// (open a transaction, run the SQL, update schema_migrations, commit —
+// marking the row dirty if the migration's SQL fails; a failed bookkeeping
+// update is rolled back without marking dirty). Migrations flagged
+// NoTransaction run their SQL outside any transaction, since DuckDB
+// rejects statements like INSTALL/LOAD inside one; the bookkeeping update
+// still happens in its own transaction.
+func (m *Migrator) applyOne(ctx context.Context, mig Migration, up bool) error {
+	sql := mig.Up
+	nextVersion := mig.Version
+	if !up {
+		sql = mig.Down
+		nextVersion = mig.Version - 1
+	}
+
+	if mig.NoTransaction {
+		if sql != "" {
+			if err := m.exec.Exec(ctx, sql); err != nil {
+				m.markDirty(ctx, mig.Version)
+				return fmt.Errorf("migration %d (%s): %w", mig.Version, mig.Name, err)
+			}
+		}
+		return m.recordVersion(ctx, nextVersion)
+	}
+
+	if err := m.exec.BeginTx(ctx); err != nil {
+		return err
+	}
+	if sql != "" {
+		if err := m.exec.Exec(ctx, sql); err != nil {
+			m.exec.Rollback(ctx)
+			m.markDirty(ctx, mig.Version)
+			return fmt.Errorf("migration %d (%s): %w", mig.Version, mig.Name, err)
+		}
+	}
+	if err := m.recordVersionNoTx(ctx, nextVersion); err != nil {
+		m.exec.Rollback(ctx)
+		return err
+	}
+	return m.exec.Commit(ctx)
+}
+
+func (m *Migrator) recordVersion(ctx context.Context, version int64) error {
+	if err := m.exec.BeginTx(ctx); err != nil {
+		return err
+	}
+	if err := m.recordVersionNoTx(ctx, version); err != nil {
+		m.exec.Rollback(ctx)
+		return err
+	}
+	return m.exec.Commit(ctx)
+}
+
+func (m *Migrator) recordVersionNoTx(ctx context.Context, version int64) error {
+	if err := m.exec.Exec(ctx, fmt.Sprintf("DELETE FROM %s", SchemaMigrationsTable)); err != nil {
+		return err
+	}
+	return m.exec.Exec(ctx, fmt.Sprintf(
+		"INSERT INTO %s (version, dirty, applied_at) VALUES (%d, false, now())",
+		SchemaMigrationsTable, version,
+	))
+}
+
+// markDirty records version (the migration that failed, not the
+// previously-applied version) as the sole schema_migrations row, same as
+// recordVersionNoTx, just with dirty=true. It is best-effort: a failure
+// here doesn't override the caller's original error.
+func (m *Migrator) markDirty(ctx context.Context, version int64) {
+	_ = m.exec.Exec(ctx, fmt.Sprintf("DELETE FROM %s", SchemaMigrationsTable))
+	_ = m.exec.Exec(ctx, fmt.Sprintf(
+		"INSERT INTO %s (version, dirty, applied_at) VALUES (%d, true, now())",
+		SchemaMigrationsTable, version,
+	))
+}
+
+// pendingMigrations returns the migrations strictly after (up) or at-or-
+// before (down) current, in the direction needed for up/down application.
+func pendingMigrations(all []Migration, current int64, up bool) []Migration {
+	var pending []Migration
+	if up {
+		for _, mig := range all {
+			if mig.Version > current {
+				pending = append(pending, mig)
+			}
+		}
+		return pending
+	}
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i].Version <= current {
+			pending = append(pending, all[i])
+		}
+	}
+	return pending
+}
@@ -0,0 +1,84 @@
+package migrations
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// filenamePattern matches "NNNN_name.up.sql" / "NNNN_name.down.sql".
+var filenamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// FSSource discovers migrations from any fs.FS, which covers both
+// os.DirFS (a plain directory on disk) and embed.FS (migrations compiled
+// into the binary).
+type FSSource struct {
+	fsys fs.FS
+}
+
+// NewFSSource returns a Source that reads NNNN_name.{up,down}.sql pairs
+// from the root of fsys.
+func NewFSSource(fsys fs.FS) *FSSource {
+	return &FSSource{fsys: fsys}
+}
+
+// Migrations implements Source.
+func (s *FSSource) Migrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(s.fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("migrations: failed to read source: %w", err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		m := filenamePattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migrations: invalid version in %q: %w", entry.Name(), err)
+		}
+		name, direction := m[2], m[3]
+
+		contents, err := fs.ReadFile(s.fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("migrations: failed to read %q: %w", entry.Name(), err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: name}
+			byVersion[version] = mig
+		}
+		if direction == "up" {
+			mig.Up = string(contents)
+		} else {
+			mig.Down = string(contents)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// StaticSource is a Source backed by an in-memory slice, useful for tests
+// and for SQL migrations assembled programmatically in Go.
+type StaticSource []Migration
+
+// Migrations implements Source.
+func (s StaticSource) Migrations() ([]Migration, error) {
+	sorted := make([]Migration, len(s))
+	copy(sorted, s)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return sorted, nil
+}
@@ -0,0 +1,151 @@
+//! # Migrations Adapter for the Smoke Test
+//!
+//! Adapts the existing duckdbQuery/duckdbOpen wrappers to
+//! migrations.Executor so the smoke test can exercise the migrations
+//! package the same way a real caller would: bring an in-memory database
+//! from empty to a known Flock-enabled state via Migrator.Up.
+
+package main
+
+/*
+#cgo CFLAGS: -I.
+#include "duckdb_ffi.h"
+*/
+import "C"
+import (
+	"context"
+	"fmt"
+
+	"kcura_go/migrations"
+)
+
+// smokeTestExecutor implements migrations.Executor over a plain
+// duckdb_connection using the wrappers already defined in
+// smoke_go_simple.go.
+type smokeTestExecutor struct {
+	conn C.duckdb_connection
+}
+
+func (e *smokeTestExecutor) Exec(ctx context.Context, sql string) error {
+	result, err := duckdbQuery(e.conn, sql)
+	if err != nil {
+		return err
+	}
+	duckdbDestroyResult(&result)
+	return nil
+}
+
+func (e *smokeTestExecutor) QueryVersion(ctx context.Context) (int64, bool, bool, error) {
+	result, err := duckdbQuery(e.conn, fmt.Sprintf(
+		"SELECT version, dirty FROM %s ORDER BY version DESC LIMIT 1", migrations.SchemaMigrationsTable,
+	))
+	if err != nil {
+		// schema_migrations doesn't exist yet.
+		return 0, false, false, nil
+	}
+	defer duckdbDestroyResult(&result)
+
+	if duckdbRowCount(result) == 0 {
+		return 0, false, false, nil
+	}
+	version := duckdbValueInt64(result, 0, 0)
+	dirty := bool(C.duckdb_value_boolean(&result, 1, 0))
+	return version, dirty, true, nil
+}
+
+func (e *smokeTestExecutor) BeginTx(ctx context.Context) error  { return e.Exec(ctx, "BEGIN TRANSACTION") }
+func (e *smokeTestExecutor) Commit(ctx context.Context) error   { return e.Exec(ctx, "COMMIT") }
+func (e *smokeTestExecutor) Rollback(ctx context.Context) error { return e.Exec(ctx, "ROLLBACK") }
+
+// testMigrationsBringUpFlockState drives an in-memory database from empty
+// to a known Flock-enabled state entirely through the migrations package,
+// verifying both the schema bookkeeping table and the loaded extension.
+func testMigrationsBringUpFlockState() error {
+	db, err := duckdbOpen(":memory:")
+	if err != nil {
+		return err
+	}
+	defer duckdbClose(&db)
+
+	conn, err := duckdbConnect(db)
+	if err != nil {
+		return err
+	}
+	defer duckdbDisconnect(&conn)
+
+	src := migrations.StaticSource{
+		{Version: 1, Name: "create_documents", Up: "CREATE TABLE documents (id BIGINT, body VARCHAR)", Down: "DROP TABLE documents"},
+		{Version: 2, Name: "load_flock", Up: "INSTALL flock FROM community; LOAD flock", NoTransaction: true},
+	}
+
+	migrator := migrations.New(&smokeTestExecutor{conn: conn}, src)
+	ctx := context.Background()
+
+	if err := migrator.Up(ctx, 0); err != nil {
+		return fmt.Errorf("migrator.Up failed: %v", err)
+	}
+
+	version, dirty, err := migrator.Version(ctx)
+	if err != nil {
+		return fmt.Errorf("migrator.Version failed: %v", err)
+	}
+	if version != 2 {
+		return fmt.Errorf("expected version 2, got %d", version)
+	}
+	if dirty {
+		return fmt.Errorf("expected a clean migration state")
+	}
+
+	result, err := duckdbQuery(conn, "SELECT extension_name FROM duckdb_extensions() WHERE extension_name = 'flock'")
+	if err != nil {
+		return err
+	}
+	defer duckdbDestroyResult(&result)
+	if duckdbRowCount(result) != 1 {
+		return fmt.Errorf("expected flock extension to be loaded after migration")
+	}
+
+	return nil
+}
+
+// testMigrationsDirtyStateDetected verifies that a failing migration
+// leaves the database marked dirty, and that Version() (via
+// smokeTestExecutor.QueryVersion) actually observes that flag rather than
+// always reporting clean.
+func testMigrationsDirtyStateDetected() error {
+	db, err := duckdbOpen(":memory:")
+	if err != nil {
+		return err
+	}
+	defer duckdbClose(&db)
+
+	conn, err := duckdbConnect(db)
+	if err != nil {
+		return err
+	}
+	defer duckdbDisconnect(&conn)
+
+	src := migrations.StaticSource{
+		{Version: 1, Name: "bad_migration", Up: "SELECT * FROM this_table_does_not_exist"},
+	}
+
+	migrator := migrations.New(&smokeTestExecutor{conn: conn}, src)
+	ctx := context.Background()
+
+	if err := migrator.Up(ctx, 0); err == nil {
+		return fmt.Errorf("expected the failing migration to return an error")
+	}
+
+	version, dirty, err := migrator.Version(ctx)
+	if err != nil {
+		return fmt.Errorf("migrator.Version failed: %v", err)
+	}
+	if version != 1 {
+		return fmt.Errorf("expected the dirty row to record version 1, got %d", version)
+	}
+	if !dirty {
+		return fmt.Errorf("expected Version() to report dirty=true after a failed migration")
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+//! # Multi-Statement SQL Execution for Frozen DuckDB
+//!
+//! `duckdb_query` only ever returns the first statement's `duckdb_result`
+//! when handed a semicolon-separated batch, silently dropping the results
+//! of every statement after it. This wraps `duckdb_extract_statements` +
+//! `duckdb_prepare_extracted_statement` so callers can submit a batch like
+//! `INSTALL flock FROM community; LOAD flock; SELECT llm_complete(...)` and
+//! get a result (or error) back per statement.
+
+package main
+
+/*
+#cgo CFLAGS: -I.
+#include "duckdb_ffi.h"
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Result is a single statement's outcome within a multi-statement batch.
+type Result struct {
+	Result C.duckdb_result
+}
+
+// StatementIter yields one duckdb_result per statement in a batch, stopping
+// at the first statement that errors. Callers must call Close when done.
+type StatementIter struct {
+	conn       C.duckdb_connection
+	extracted  C.duckdb_extracted_statements
+	count      C.idx_t
+	index      C.idx_t
+	FailedStmt int // -1 until a statement fails, then the 0-based index that failed
+}
+
+// QueryMulti runs every statement in sql in order and returns all results.
+// Statements 0..N-1 are only as committed as DuckDB's autocommit makes each
+// individual statement: a later failing statement does not roll back the
+// side effects of the statements that already succeeded before it.
+func QueryMulti(conn C.duckdb_connection, sql string) ([]Result, error) {
+	iter, err := QueryMultiIter(conn, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var results []Result
+	for {
+		result, err := iter.Next()
+		if err != nil {
+			return results, err
+		}
+		if result == nil {
+			break
+		}
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+// QueryMultiIter extracts the individual statements in sql and returns an
+// iterator that prepares and executes them one at a time.
+func QueryMultiIter(conn C.duckdb_connection, sql string) (*StatementIter, error) {
+	cSQL := C.CString(sql)
+	defer C.free(unsafe.Pointer(cSQL))
+
+	var extracted C.duckdb_extracted_statements
+	count := C.duckdb_extract_statements(conn, cSQL, &extracted)
+	if count == 0 {
+		errorMsg := C.duckdb_extract_statements_error(extracted)
+		C.duckdb_destroy_extracted(&extracted)
+		if errorMsg != nil {
+			return nil, fmt.Errorf("failed to extract statements: %s", C.GoString(errorMsg))
+		}
+		return nil, fmt.Errorf("no statements found in: %s", sql)
+	}
+
+	return &StatementIter{
+		conn:       conn,
+		extracted:  extracted,
+		count:      count,
+		index:      0,
+		FailedStmt: -1,
+	}, nil
+}
+
+// Next prepares and executes the next statement in the batch. It returns
+// (nil, nil) once every statement has run. On a statement failure it
+// records the 0-based index in FailedStmt and returns the error; the
+// iterator does not advance past a failed statement.
+func (it *StatementIter) Next() (*C.duckdb_result, error) {
+	if it.index >= it.count {
+		return nil, nil
+	}
+
+	var stmt C.duckdb_prepared_statement
+	state := C.duckdb_prepare_extracted_statement(it.conn, it.extracted, it.index, &stmt)
+	if state != C.DuckDBSuccess {
+		it.FailedStmt = int(it.index)
+		errorMsg := C.duckdb_prepare_error(stmt)
+		C.duckdb_destroy_prepare(&stmt)
+		if errorMsg != nil {
+			return nil, fmt.Errorf("statement %d failed to prepare: %s", it.index, C.GoString(errorMsg))
+		}
+		return nil, fmt.Errorf("statement %d failed to prepare", it.index)
+	}
+
+	var result C.duckdb_result
+	state = C.duckdb_execute_prepared(stmt, &result)
+	C.duckdb_destroy_prepare(&stmt)
+	if state != C.DuckDBSuccess {
+		it.FailedStmt = int(it.index)
+		errorMsg := C.duckdb_result_error(&result)
+		if errorMsg != nil {
+			return nil, fmt.Errorf("statement %d failed: %s", it.index, C.GoString(errorMsg))
+		}
+		return nil, fmt.Errorf("statement %d failed", it.index)
+	}
+
+	it.index++
+	return &result, nil
+}
+
+// Close releases the extracted statements. Safe to call even if Next
+// returned an error partway through the batch.
+func (it *StatementIter) Close() {
+	C.duckdb_destroy_extracted(&it.extracted)
+}
+
+// testMultiStatementExecution verifies QueryMulti surfaces a result per
+// statement and testMultiStatementPartialFailure verifies statements before
+// a failing one remain in effect (DuckDB autocommit semantics, not a single
+// rolled-back transaction).
+func testMultiStatementExecution() error {
+	db, err := duckdbOpen(":memory:")
+	if err != nil {
+		return err
+	}
+	defer duckdbClose(&db)
+
+	conn, err := duckdbConnect(db)
+	if err != nil {
+		return err
+	}
+	defer duckdbDisconnect(&conn)
+
+	results, err := QueryMulti(conn, "CREATE TABLE t (i INTEGER); INSERT INTO t VALUES (1), (2); SELECT * FROM t ORDER BY i")
+	if err != nil {
+		return fmt.Errorf("QueryMulti failed: %v", err)
+	}
+	defer func() {
+		for i := range results {
+			duckdbDestroyResult(&results[i].Result)
+		}
+	}()
+
+	if len(results) != 3 {
+		return fmt.Errorf("expected 3 statement results, got %d", len(results))
+	}
+
+	lastResult := results[2].Result
+	if duckdbRowCount(lastResult) != 2 {
+		return fmt.Errorf("expected final SELECT to return 2 rows, got %d", duckdbRowCount(lastResult))
+	}
+
+	return nil
+}
+
+func testMultiStatementPartialFailure() error {
+	db, err := duckdbOpen(":memory:")
+	if err != nil {
+		return err
+	}
+	defer duckdbClose(&db)
+
+	conn, err := duckdbConnect(db)
+	if err != nil {
+		return err
+	}
+	defer duckdbDisconnect(&conn)
+
+	iter, err := QueryMultiIter(conn, "CREATE TABLE t (i INTEGER); INSERT INTO t VALUES (1); SELECT * FROM nonexistent_table")
+	if err != nil {
+		return fmt.Errorf("QueryMultiIter failed: %v", err)
+	}
+	defer iter.Close()
+
+	var ran int
+	for {
+		result, err := iter.Next()
+		if err != nil {
+			if iter.FailedStmt != 2 {
+				return fmt.Errorf("expected statement 2 to fail, got %d", iter.FailedStmt)
+			}
+			break
+		}
+		if result == nil {
+			return fmt.Errorf("expected the third statement to fail, but the batch completed")
+		}
+		duckdbDestroyResult(result)
+		ran++
+	}
+	if ran != 2 {
+		return fmt.Errorf("expected 2 statements to succeed before the failure, got %d", ran)
+	}
+
+	// Statements before the failure already committed (autocommit), so the
+	// table and its row are still visible on this connection.
+	check, err := duckdbQuery(conn, "SELECT count(*) FROM t")
+	if err != nil {
+		return fmt.Errorf("table from earlier statement should still exist: %v", err)
+	}
+	defer duckdbDestroyResult(&check)
+	if duckdbValueInt32(check, 0, 0) != 1 {
+		return fmt.Errorf("expected 1 row to remain committed from statement 1")
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+//! # Transactions and Context-Aware Query Cancellation
+//!
+//! The wrappers previously ran every query in autocommit with no
+//! transaction primitives at all. This adds BeginTx with read-only
+//! snapshot support, and duckdbQueryCtx, a context-aware variant of
+//! duckdbQuery, so callers can enforce per-call timeouts and have them
+//! interrupt the connection instead of just abandoning the goroutine.
+//! duckdbQuery itself takes no context.
+
+package main
+
+/*
+#cgo CFLAGS: -I.
+#include "duckdb_ffi.h"
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"context"
+	"fmt"
+)
+
+// Isolation mirrors DuckDB's transaction isolation, which is always
+// snapshot isolation; the field exists so callers can express intent and
+// so a future stricter mode has somewhere to go.
+type Isolation int
+
+const (
+	IsolationSnapshot Isolation = iota
+)
+
+// TxOptions configures BeginTx.
+type TxOptions struct {
+	ReadOnly  bool
+	Isolation Isolation
+}
+
+// Tx is an in-progress DuckDB transaction on a single connection. DuckDB
+// connections are not safe for concurrent use, so neither is a Tx.
+type Tx struct {
+	conn C.duckdb_connection
+	ctx  context.Context
+	done bool
+}
+
+// BeginTx starts a transaction on conn. When opts.ReadOnly is set, it
+// issues BEGIN TRANSACTION READ ONLY so the transaction captures a
+// snapshot that does not observe commits from other connections until it
+// itself commits.
+func BeginTx(ctx context.Context, conn C.duckdb_connection, opts *TxOptions) (*Tx, error) {
+	sql := "BEGIN TRANSACTION"
+	if opts != nil && opts.ReadOnly {
+		sql = "BEGIN TRANSACTION READ ONLY"
+	}
+	if _, err := duckdbQueryCtx(ctx, conn, sql); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	return &Tx{conn: conn, ctx: ctx}, nil
+}
+
+// Query runs sql within the transaction, honoring ctx.Done() by
+// interrupting the connection.
+func (tx *Tx) Query(sql string) (C.duckdb_result, error) {
+	if tx.done {
+		var empty C.duckdb_result
+		return empty, fmt.Errorf("transaction already committed or rolled back")
+	}
+	return duckdbQueryCtx(tx.ctx, tx.conn, sql)
+}
+
+// Exec runs sql within the transaction for its side effects, discarding
+// the result.
+func (tx *Tx) Exec(sql string) error {
+	result, err := tx.Query(sql)
+	if err != nil {
+		return err
+	}
+	duckdbDestroyResult(&result)
+	return nil
+}
+
+// Commit ends the transaction, persisting its changes.
+func (tx *Tx) Commit() error {
+	return tx.end("COMMIT")
+}
+
+// Rollback ends the transaction, discarding its changes.
+func (tx *Tx) Rollback() error {
+	return tx.end("ROLLBACK")
+}
+
+func (tx *Tx) end(sql string) error {
+	if tx.done {
+		return nil
+	}
+	tx.done = true
+	result, err := duckdbQueryCtx(tx.ctx, tx.conn, sql)
+	if err != nil {
+		return err
+	}
+	duckdbDestroyResult(&result)
+	return nil
+}
+
+// duckdbQueryCtx runs duckdb_query on a background goroutine and races it
+// against ctx.Done(), calling duckdb_interrupt on the connection if the
+// context is cancelled or its deadline expires before the query finishes.
+func duckdbQueryCtx(ctx context.Context, conn C.duckdb_connection, sql string) (C.duckdb_result, error) {
+	type queryOutcome struct {
+		result C.duckdb_result
+		err    error
+	}
+	outcome := make(chan queryOutcome, 1)
+
+	go func() {
+		result, err := duckdbQuery(conn, sql)
+		outcome <- queryOutcome{result, err}
+	}()
+
+	select {
+	case o := <-outcome:
+		return o.result, o.err
+	case <-ctx.Done():
+		C.duckdb_interrupt(conn)
+		o := <-outcome // wait for the interrupted query to actually return
+		if o.err == nil {
+			duckdbDestroyResult(&o.result)
+		}
+		var empty C.duckdb_result
+		return empty, ctx.Err()
+	}
+}
+
+// testReadOnlySnapshotIsolation starts a read-only transaction on one
+// connection, inserts a row from a second connection, and verifies the
+// first transaction's snapshot does not observe the insert until after it
+// commits and re-queries on a fresh transaction.
+func testReadOnlySnapshotIsolation() error {
+	db, err := duckdbOpen(":memory:")
+	if err != nil {
+		return err
+	}
+	defer duckdbClose(&db)
+
+	readerConn, err := duckdbConnect(db)
+	if err != nil {
+		return err
+	}
+	defer duckdbDisconnect(&readerConn)
+
+	writerConn, err := duckdbConnect(db)
+	if err != nil {
+		return err
+	}
+	defer duckdbDisconnect(&writerConn)
+
+	setup, err := duckdbQuery(writerConn, "CREATE TABLE snap (id INTEGER)")
+	if err != nil {
+		return err
+	}
+	duckdbDestroyResult(&setup)
+
+	ctx := context.Background()
+	tx, err := BeginTx(ctx, readerConn, &TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("BeginTx failed: %v", err)
+	}
+
+	insert, err := duckdbQuery(writerConn, "INSERT INTO snap VALUES (1)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	duckdbDestroyResult(&insert)
+
+	result, err := tx.Query("SELECT count(*) FROM snap")
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("snapshot query failed: %v", err)
+	}
+	countDuringTx := duckdbValueInt32(result, 0, 0)
+	duckdbDestroyResult(&result)
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit failed: %v", err)
+	}
+
+	if countDuringTx != 0 {
+		return fmt.Errorf("expected snapshot to not see concurrent insert, saw %d rows", countDuringTx)
+	}
+
+	after, err := duckdbQuery(readerConn, "SELECT count(*) FROM snap")
+	if err != nil {
+		return err
+	}
+	defer duckdbDestroyResult(&after)
+	if duckdbValueInt32(after, 0, 0) != 1 {
+		return fmt.Errorf("expected insert to be visible after the snapshot tx committed")
+	}
+
+	return nil
+}
+
+// testQueryContextTimeout verifies duckdbQueryCtx enforces ctx's deadline
+// on a long-running query.
+func testQueryContextTimeout() error {
+	db, err := duckdbOpen(":memory:")
+	if err != nil {
+		return err
+	}
+	defer duckdbClose(&db)
+
+	conn, err := duckdbConnect(db)
+	if err != nil {
+		return err
+	}
+	defer duckdbDisconnect(&conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50_000_000) // 50ms
+	defer cancel()
+
+	_, err = duckdbQueryCtx(ctx, conn, "SELECT count(*) FROM range(1000000000000)")
+	if err == nil {
+		return fmt.Errorf("expected timeout error")
+	}
+	if ctx.Err() == nil {
+		return fmt.Errorf("expected context deadline to have been exceeded")
+	}
+
+	return nil
+}
@@ -191,6 +191,10 @@ func duckdbValueInt32(result C.duckdb_result, col, row int) int32 {
 	return int32(C.duckdb_value_int32(&result, C.idx_t(col), C.idx_t(row)))
 }
 
+func duckdbValueInt64(result C.duckdb_result, col, row int) int64 {
+	return int64(C.duckdb_value_int64(&result, C.idx_t(col), C.idx_t(row)))
+}
+
 // Test functions
 func testLibraryVersion() error {
 	version := duckdbLibraryVersion()
@@ -337,12 +341,16 @@ func testFlockLLMFunctions() error {
 	}
 	defer duckdbDisconnect(&conn)
 	
-	// Load Flock extension
-	result, err := duckdbQuery(conn, "INSTALL flock FROM community; LOAD flock")
+	// Load Flock extension. This is two statements, so it goes through
+	// QueryMulti rather than duckdb_query, which would silently discard
+	// the LOAD result.
+	results, err := QueryMulti(conn, "INSTALL flock FROM community; LOAD flock")
 	if err != nil {
 		return fmt.Errorf("failed to load Flock: %v", err)
 	}
-	duckdbDestroyResult(&result)
+	for i := range results {
+		duckdbDestroyResult(&results[i].Result)
+	}
 	
 	// Test that LLM functions are available (they may fail without models, but should exist)
 	queries := []string{
@@ -438,6 +446,37 @@ func main() {
 	// Flock LLM extension tests
 	suite.RunTest("Flock Extension Loading", testFlockExtension)
 	suite.RunTest("Flock LLM Functions", testFlockLLMFunctions)
-	
+
+	// Arrow batch streaming
+	suite.RunTest("Arrow Batch Streaming", testArrowBatchStreaming)
+	suite.RunTest("Arrow Bound Parameter Query", testArrowBoundParameterQuery)
+	suite.RunTest("Arrow vs Row-by-Row Benchmark", benchmarkArrowVsRowByRow)
+
+	// Async / cancellable query execution
+	suite.RunTest("Async Query Result", testAsyncQueryResult)
+	suite.RunTest("Async Query Cancellation", testAsyncQueryCancellation)
+	suite.RunTest("Async Query Deadline", testAsyncQueryDeadline)
+
+	// Multi-statement SQL execution
+	suite.RunTest("Multi-Statement Execution", testMultiStatementExecution)
+	suite.RunTest("Multi-Statement Partial Failure", testMultiStatementPartialFailure)
+
+	// Prepared statements with typed parameter binding
+	suite.RunTest("Prepared Statement Round Trip", testPreparedStatementRoundTrip)
+	suite.RunTest("Prepared Statement BindAll", testPreparedStatementBindAll)
+	suite.RunTest("Prepared Statement BindList/BindStruct", testPreparedStatementBindListAndStruct)
+
+	// Schema migrations
+	suite.RunTest("Migrations Bring Up Flock State", testMigrationsBringUpFlockState)
+	suite.RunTest("Migrations Dirty State Detected", testMigrationsDirtyStateDetected)
+
+	// Transactions and context propagation
+	suite.RunTest("Read-Only Snapshot Isolation", testReadOnlySnapshotIsolation)
+	suite.RunTest("Query Context Timeout", testQueryContextTimeout)
+
+	// Bulk load via the Appender API
+	suite.RunTest("Appender Bulk Load", testAppenderBulkLoad)
+	suite.RunTest("Appender vs INSERT Benchmark", benchmarkAppenderVsInsert)
+
 	suite.Summary()
 }